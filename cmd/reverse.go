@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/CelikE/nvim-go/internal/reverse"
+)
+
+// runReverse scaffolds Go struct declarations from a live SQL
+// database schema.
+func runReverse() error {
+	fs := flag.NewFlagSet("reverse", flag.ContinueOnError)
+	dsn := fs.String("dsn", "", "database connection string")
+	dialect := fs.String("dialect", "", "database dialect: mysql, postgres, or sqlite3")
+	tables := fs.String("tables", "", "comma-separated table names to generate; empty means every table")
+	prefix := fs.String("prefix", "", "table name prefix to strip; when set, a TableName() method is also generated")
+	pkg := fs.String("package", "models", "generated package name")
+	nameCase := fs.String("case", "snake", "tag value casing: snake or camel")
+	tagSets := fs.String("tags", "db", "comma-separated tag sets to emit: db, json, xorm, gorm")
+	outDir := fs.String("out", "", "write generated files under this directory instead of printing JSON")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+
+	if *dsn == "" || *dialect == "" {
+		return fmt.Errorf("usage: nvim-go reverse --dsn <dsn> --dialect <mysql|postgres|sqlite3> [--tables t1,t2] [--prefix p] [--package name] [--case snake|camel] [--tags db,json] [--out dir]")
+	}
+
+	files, err := reverse.Generate(reverse.Options{
+		DSN:         *dsn,
+		Dialect:     reverse.Dialect(*dialect),
+		Tables:      splitNonEmpty(*tables),
+		TablePrefix: *prefix,
+		Package:     *pkg,
+		NameCase:    reverse.NameCase(*nameCase),
+		Tags:        splitNonEmpty(*tagSets),
+		OutDir:      *outDir,
+	})
+	if err != nil {
+		return fmt.Errorf("reversing schema: %w", err)
+	}
+
+	if *outDir != "" {
+		fmt.Printf("wrote %d file(s) to %s\n", len(files), *outDir)
+		return nil
+	}
+
+	return outputJSON(files)
+}
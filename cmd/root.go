@@ -24,6 +24,12 @@ func Execute() error {
 		return runInterface()
 	case "imports":
 		return runImports()
+	case "serve":
+		return runServe()
+	case "tags":
+		return runTags()
+	case "reverse":
+		return runReverse()
 	case "version":
 		fmt.Println("nvim-go v1.0.0")
 		return nil
@@ -43,6 +49,9 @@ Commands:
   struct     Get struct information at position
   interface  Get interface information
   imports    Analyze and organize imports
+  serve      Run a long-lived JSON-RPC server over stdio or a socket
+  tags       Add, remove, or rewrite struct field tags
+  reverse    Generate Go structs from a live SQL database schema
   version    Show version
   help       Show this help
 
@@ -50,7 +59,10 @@ Usage:
   nvim-go parse <file> [line] [col]
   nvim-go struct <file> <line>
   nvim-go interface <file> <name>
-  nvim-go imports <file>`)
+  nvim-go imports [--local prefix] [--write] [--json] <file>
+  nvim-go serve [--socket <path>]
+  nvim-go tags --file <file> --line <line> [--add k1,k2] [--remove k1,k2]
+  nvim-go reverse --dsn <dsn> --dialect <mysql|postgres|sqlite3>`)
 }
 
 // outputJSON writes data as JSON to stdout.
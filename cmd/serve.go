@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/CelikE/nvim-go/internal/parser"
+	"github.com/CelikE/nvim-go/internal/rpc"
+)
+
+// runServe starts the JSON-RPC server used by the Neovim plugin in
+// place of forking the CLI for every query. Requests share a cache of
+// parsed files keyed by URI and content hash, so repeated
+// parser/structAt or parser/interfaceByName calls against an unchanged
+// buffer skip re-parsing.
+func runServe() error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	socket := fs.String("socket", "", "serve on a Unix socket instead of stdio")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+
+	docs := newDocumentCache()
+	srv := rpc.NewServer()
+	srv.Handle("parser/parseFile", docs.parseFile)
+	srv.Handle("parser/structAt", docs.structAt)
+	srv.Handle("parser/interfaceByName", docs.interfaceByName)
+	srv.Handle("imports/organize", docs.organizeImports)
+	srv.Handle("textDocument/didChange", docs.didChange)
+
+	if *socket == "" {
+		return srv.Serve(os.Stdin, os.Stdout)
+	}
+
+	ln, err := net.Listen("unix", *socket)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", *socket, err)
+	}
+	defer ln.Close()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return fmt.Errorf("accepting connection: %w", err)
+	}
+	defer conn.Close()
+
+	return srv.Serve(conn, conn)
+}
+
+// documentCache holds the most recently parsed *parser.FileInfo for
+// each open document, invalidated whenever its content hash changes.
+type documentCache struct {
+	mu   sync.Mutex
+	docs map[string]*cachedDoc
+}
+
+type cachedDoc struct {
+	hash string
+	text string
+	info *parser.FileInfo
+}
+
+func newDocumentCache() *documentCache {
+	return &documentCache{docs: make(map[string]*cachedDoc)}
+}
+
+func hashText(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// fileParams is the common shape of parser/* requests: a document URI
+// plus optional full text for an unsaved buffer.
+type fileParams struct {
+	URI  string `json:"uri"`
+	Text string `json:"text,omitempty"`
+}
+
+// get returns the parsed info for params, reusing the cache when
+// params.Text matches the last hash seen for params.URI. It always
+// leaves cachedDoc.text populated with the source actually parsed -
+// the provided text, or the on-disk content when none was given - so
+// later callers (e.g. organizeImports) can recover it via cachedText.
+func (d *documentCache) get(params fileParams) (*parser.FileInfo, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if params.Text != "" {
+		hash := hashText(params.Text)
+		if doc, ok := d.docs[params.URI]; ok && doc.hash == hash {
+			return doc.info, nil
+		}
+
+		info, err := parser.ParseSource(params.URI, params.Text)
+		if err != nil {
+			return nil, err
+		}
+		d.docs[params.URI] = &cachedDoc{hash: hash, text: params.Text, info: info}
+		return info, nil
+	}
+
+	if doc, ok := d.docs[params.URI]; ok {
+		return doc.info, nil
+	}
+
+	src, err := os.ReadFile(params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parser.ParseSource(params.URI, src)
+	if err != nil {
+		return nil, err
+	}
+	d.docs[params.URI] = &cachedDoc{hash: hashText(string(src)), text: string(src), info: info}
+	return info, nil
+}
+
+// cachedText returns the source text last parsed for uri, or "" if
+// uri has never been seen.
+func (d *documentCache) cachedText(uri string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if doc, ok := d.docs[uri]; ok {
+		return doc.text
+	}
+	return ""
+}
+
+func (d *documentCache) parseFile(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params fileParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, rpc.NewError(rpc.InvalidParams, err)
+	}
+	return d.get(params)
+}
+
+type structAtParams struct {
+	fileParams
+	Line int `json:"line"`
+}
+
+func (d *documentCache) structAt(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params structAtParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, rpc.NewError(rpc.InvalidParams, err)
+	}
+
+	info, err := d.get(params.fileParams)
+	if err != nil {
+		return nil, rpc.NewError(rpc.InternalError, err)
+	}
+
+	for _, s := range info.Structs {
+		if params.Line >= s.StartLine && params.Line <= s.EndLine {
+			return s, nil
+		}
+	}
+	return nil, rpc.NewError(rpc.InvalidParams, fmt.Errorf("no struct found at line %d", params.Line))
+}
+
+type interfaceByNameParams struct {
+	fileParams
+	Name string `json:"name"`
+}
+
+func (d *documentCache) interfaceByName(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params interfaceByNameParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, rpc.NewError(rpc.InvalidParams, err)
+	}
+
+	info, err := d.get(params.fileParams)
+	if err != nil {
+		return nil, rpc.NewError(rpc.InternalError, err)
+	}
+
+	for _, iface := range info.Interfaces {
+		if iface.Name == params.Name {
+			return iface, nil
+		}
+	}
+	return nil, rpc.NewError(rpc.InvalidParams, fmt.Errorf("interface %q not found", params.Name))
+}
+
+type organizeImportsParams struct {
+	fileParams
+	Local string `json:"local,omitempty"`
+}
+
+func (d *documentCache) organizeImports(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params organizeImportsParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, rpc.NewError(rpc.InvalidParams, err)
+	}
+
+	before, err := d.get(params.fileParams)
+	if err != nil {
+		return nil, rpc.NewError(rpc.InternalError, err)
+	}
+
+	// params.Text == "" means "reuse the cached/on-disk content", the
+	// same convention documentCache.get follows; d.get above guarantees
+	// the cache holds that content under params.URI by now.
+	text := params.Text
+	if text == "" {
+		text = d.cachedText(params.URI)
+	}
+
+	organized, err := organizeImports(params.URI, []byte(text), params.Local)
+	if err != nil {
+		return nil, rpc.NewError(rpc.InternalError, err)
+	}
+
+	after, err := parser.ParseSource(params.URI, organized)
+	if err != nil {
+		return nil, rpc.NewError(rpc.InternalError, err)
+	}
+
+	added, removed := diffImports(before.Imports, after.Imports)
+	return ImportEdit{
+		StartLine: 1,
+		EndLine:   strings.Count(text, "\n") + 1,
+		NewText:   string(organized),
+		Added:     added,
+		Removed:   removed,
+	}, nil
+}
+
+// didChangeParams is the notification sent whenever the Neovim buffer
+// contents change, so the cache can invalidate or eagerly reparse.
+type didChangeParams struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+func (d *documentCache) didChange(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params didChangeParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, rpc.NewError(rpc.InvalidParams, err)
+	}
+
+	info, err := parser.ParseSource(params.URI, params.Text)
+	if err != nil {
+		// Keep the stale cache entry; the buffer is mid-edit and
+		// transiently invalid Go is expected.
+		return nil, nil
+	}
+
+	d.mu.Lock()
+	d.docs[params.URI] = &cachedDoc{hash: hashText(params.Text), text: params.Text, info: info}
+	d.mu.Unlock()
+	return nil, nil
+}
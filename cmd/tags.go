@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/CelikE/nvim-go/internal/tags"
+)
+
+func runTags() error {
+	fs := flag.NewFlagSet("tags", flag.ContinueOnError)
+	file := fs.String("file", "", "path to the Go source file")
+	line := fs.Int("line", 0, "line number within the struct to modify")
+	add := fs.String("add", "", "comma-separated tag keys to add, e.g. json,xml")
+	remove := fs.String("remove", "", "comma-separated tag keys to remove")
+	transform := fs.String("transform", "keep", "name transform: keep, snakecase, camelcase, pascalcase, kebabcase")
+	options := fs.String("options", "", "comma-separated per-tag options, e.g. json=omitempty")
+	write := fs.Bool("write", false, "write the result back to --file instead of printing it")
+	skipUnexported := fs.Bool("skip-unexported", true, "skip unexported fields")
+	skipEmbedded := fs.Bool("skip-embedded", true, "skip embedded fields")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+
+	if *file == "" || *line == 0 {
+		return fmt.Errorf("usage: nvim-go tags --file <file> --line <line> [--add k1,k2] [--remove k1,k2] [--transform t] [--options json=omitempty] [--write]")
+	}
+
+	result, err := tags.Apply(tags.Options{
+		File:           *file,
+		Line:           *line,
+		Add:            splitNonEmpty(*add),
+		Remove:         splitNonEmpty(*remove),
+		Transform:      tags.Transform(*transform),
+		TagOptions:     parseTagOptions(*options),
+		SkipUnexported: *skipUnexported,
+		SkipEmbedded:   *skipEmbedded,
+	})
+	if err != nil {
+		return fmt.Errorf("rewriting tags: %w", err)
+	}
+
+	if *write {
+		return os.WriteFile(*file, []byte(result.Source), 0o644)
+	}
+
+	return outputJSON(result)
+}
+
+func splitNonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// parseTagOptions parses "json=omitempty" style pairs into per-tag
+// option lists, so --options attaches options to the specific tags
+// named in --add rather than to all of them.
+func parseTagOptions(s string) map[string][]string {
+	if s == "" {
+		return nil
+	}
+
+	result := make(map[string][]string)
+	for _, part := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		result[key] = append(result[key], value)
+	}
+	return result
+}
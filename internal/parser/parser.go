@@ -17,6 +17,74 @@ type FileInfo struct {
 	Functions  []FuncInfo   `json:"functions"`
 }
 
+// docText renders a comment group as plain text for JSON output: the
+// "//" or "/* */" markers are stripped from each line, and blank
+// lines between paragraphs are preserved so a Neovim hover UI can
+// render godoc the way `go doc` would.
+func docText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, c := range cg.List {
+		text := c.Text
+		switch {
+		case strings.HasPrefix(text, "//"):
+			text = strings.TrimPrefix(text, "//")
+		case strings.HasPrefix(text, "/*"):
+			text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+		}
+		text = strings.TrimPrefix(text, " ")
+		lines = append(lines, strings.Split(text, "\n")...)
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// docFor returns the rendered doc comment for the first of nodes that
+// has one attached in cmap. Grouped `const`/`var`/`type (...)` blocks
+// attach each spec's doc to the spec itself, while a lone declaration
+// attaches it to the enclosing GenDecl, so callers pass both and let
+// docFor pick whichever is populated.
+func docFor(cmap ast.CommentMap, nodes ...ast.Node) string {
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		for _, group := range cmap[n] {
+			// Only a group ending before the node is a doc comment;
+			// cmap also holds trailing line comments.
+			if group.End() >= n.Pos() {
+				continue
+			}
+			if text := docText(group); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// trailingComment returns the line comment attached to node, i.e. one
+// starting on the same source line node ends on.
+func trailingComment(fset *token.FileSet, cmap ast.CommentMap, node ast.Node) string {
+	endLine := fset.Position(node.End()).Line
+	for _, group := range cmap[node] {
+		if fset.Position(group.Pos()).Line == endLine {
+			return docText(group)
+		}
+	}
+	return ""
+}
+
 // ImportInfo represents an import declaration.
 type ImportInfo struct {
 	Path      string `json:"path"`
@@ -27,35 +95,46 @@ type ImportInfo struct {
 
 // StructInfo represents a struct type declaration.
 type StructInfo struct {
-	Name      string      `json:"name"`
-	Fields    []FieldInfo `json:"fields"`
-	StartLine int         `json:"start_line"`
-	EndLine   int         `json:"end_line"`
+	Name        string      `json:"name"`
+	Doc         string      `json:"doc,omitempty"`
+	LineComment string      `json:"line_comment,omitempty"`
+	TypeParams  []ParamInfo `json:"type_params,omitempty"`
+	Fields      []FieldInfo `json:"fields"`
+	StartLine   int         `json:"start_line"`
+	EndLine     int         `json:"end_line"`
 }
 
 // FieldInfo represents a struct field.
 type FieldInfo struct {
-	Names    []string          `json:"names"`
-	Type     string            `json:"type"`
-	Tag      string            `json:"tag,omitempty"`
-	Tags     map[string]string `json:"tags,omitempty"`
-	Embedded bool              `json:"embedded,omitempty"`
+	Names       []string          `json:"names"`
+	Type        string            `json:"type"`
+	Tag         string            `json:"tag,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Embedded    bool              `json:"embedded,omitempty"`
+	Doc         string            `json:"doc,omitempty"`
+	LineComment string            `json:"line_comment,omitempty"`
 }
 
 // IfaceInfo represents an interface type declaration.
 type IfaceInfo struct {
-	Name      string       `json:"name"`
-	Methods   []MethodInfo `json:"methods"`
-	StartLine int          `json:"start_line"`
-	EndLine   int          `json:"end_line"`
+	Name        string       `json:"name"`
+	Doc         string       `json:"doc,omitempty"`
+	LineComment string       `json:"line_comment,omitempty"`
+	TypeParams  []ParamInfo  `json:"type_params,omitempty"`
+	Embedded    []string     `json:"embedded,omitempty"`
+	Methods     []MethodInfo `json:"methods"`
+	StartLine   int          `json:"start_line"`
+	EndLine     int          `json:"end_line"`
 }
 
 // MethodInfo represents a method in an interface or struct.
 type MethodInfo struct {
-	Name       string      `json:"name"`
-	Params     []ParamInfo `json:"params"`
-	Results    []ParamInfo `json:"results,omitempty"`
-	IsExported bool        `json:"is_exported"`
+	Name        string      `json:"name"`
+	Doc         string      `json:"doc,omitempty"`
+	LineComment string      `json:"line_comment,omitempty"`
+	Params      []ParamInfo `json:"params"`
+	Results     []ParamInfo `json:"results,omitempty"`
+	IsExported  bool        `json:"is_exported"`
 }
 
 // ParamInfo represents a function parameter or result.
@@ -66,19 +145,33 @@ type ParamInfo struct {
 
 // FuncInfo represents a function or method declaration.
 type FuncInfo struct {
-	Name      string      `json:"name"`
-	Receiver  *ParamInfo  `json:"receiver,omitempty"`
-	Params    []ParamInfo `json:"params"`
-	Results   []ParamInfo `json:"results,omitempty"`
-	StartLine int         `json:"start_line"`
-	EndLine   int         `json:"end_line"`
+	Name        string      `json:"name"`
+	Doc         string      `json:"doc,omitempty"`
+	LineComment string      `json:"line_comment,omitempty"`
+	TypeParams  []ParamInfo `json:"type_params,omitempty"`
+	Receiver    *ParamInfo  `json:"receiver,omitempty"`
+	Params      []ParamInfo `json:"params"`
+	Results     []ParamInfo `json:"results,omitempty"`
+	StartLine   int         `json:"start_line"`
+	EndLine     int         `json:"end_line"`
 }
 
-// ParseFile parses a Go source file and returns structured information.
+// ParseFile parses a Go source file from disk and returns structured
+// information.
 func ParseFile(filename string) (*FileInfo, error) {
+	return ParseSource(filename, nil)
+}
+
+// ParseSource parses Go source held in memory and returns structured
+// information, without touching disk. src may be a string, []byte,
+// io.Reader, or nil; nil falls back to reading filename from disk, as
+// in go/parser.ParseFile. This lets callers such as the server mode in
+// cmd/serve.go reuse a single entry point for both on-disk files and
+// unsaved editor buffers.
+func ParseSource(filename string, src any) (*FileInfo, error) {
 	fset := token.NewFileSet()
 
-	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +197,8 @@ func ParseFile(filename string) (*FileInfo, error) {
 		info.Imports = append(info.Imports, importInfo)
 	}
 
+	cmap := ast.NewCommentMap(fset, file, file.Comments)
+
 	// Walk AST for declarations
 	ast.Inspect(file, func(n ast.Node) bool {
 		switch decl := n.(type) {
@@ -117,14 +212,14 @@ func ParseFile(filename string) (*FileInfo, error) {
 
 					switch t := typeSpec.Type.(type) {
 					case *ast.StructType:
-						info.Structs = append(info.Structs, parseStruct(fset, typeSpec.Name.Name, t, decl))
+						info.Structs = append(info.Structs, parseStruct(fset, cmap, typeSpec, t, decl))
 					case *ast.InterfaceType:
-						info.Interfaces = append(info.Interfaces, parseInterface(fset, typeSpec.Name.Name, t, decl))
+						info.Interfaces = append(info.Interfaces, parseInterface(fset, cmap, typeSpec, t, decl))
 					}
 				}
 			}
 		case *ast.FuncDecl:
-			info.Functions = append(info.Functions, parseFunc(fset, decl))
+			info.Functions = append(info.Functions, parseFunc(fset, cmap, decl))
 		}
 		return true
 	})
@@ -132,20 +227,37 @@ func ParseFile(filename string) (*FileInfo, error) {
 	return info, nil
 }
 
-func parseStruct(fset *token.FileSet, name string, st *ast.StructType, decl *ast.GenDecl) StructInfo {
+// typeSpecSpan returns the start/end line of typeSpec itself for a
+// grouped `type ( ... )` block, where each spec has its own span, and
+// the enclosing decl's span for a lone declaration, where the comment
+// and doc line up with "type" rather than the spec's name.
+func typeSpecSpan(fset *token.FileSet, decl *ast.GenDecl, typeSpec *ast.TypeSpec) (start, end int) {
+	if decl.Lparen.IsValid() {
+		return fset.Position(typeSpec.Pos()).Line, fset.Position(typeSpec.End()).Line
+	}
+	return fset.Position(decl.Pos()).Line, fset.Position(decl.End()).Line
+}
+
+func parseStruct(fset *token.FileSet, cmap ast.CommentMap, typeSpec *ast.TypeSpec, st *ast.StructType, decl *ast.GenDecl) StructInfo {
+	startLine, endLine := typeSpecSpan(fset, decl, typeSpec)
 	info := StructInfo{
-		Name:      name,
-		Fields:    make([]FieldInfo, 0),
-		StartLine: fset.Position(decl.Pos()).Line,
-		EndLine:   fset.Position(decl.End()).Line,
+		Name:        typeSpec.Name.Name,
+		Doc:         docFor(cmap, typeSpec, decl),
+		LineComment: trailingComment(fset, cmap, typeSpec),
+		TypeParams:  parseFieldList(typeSpec.TypeParams),
+		Fields:      make([]FieldInfo, 0),
+		StartLine:   startLine,
+		EndLine:     endLine,
 	}
 
 	if st.Fields != nil {
 		for _, field := range st.Fields.List {
 			fieldInfo := FieldInfo{
-				Names: make([]string, 0),
-				Type:  typeToString(field.Type),
-				Tags:  make(map[string]string),
+				Names:       make([]string, 0),
+				Type:        typeToString(field.Type),
+				Tags:        make(map[string]string),
+				Doc:         docText(field.Doc),
+				LineComment: docText(field.Comment),
 			}
 
 			for _, name := range field.Names {
@@ -168,18 +280,26 @@ func parseStruct(fset *token.FileSet, name string, st *ast.StructType, decl *ast
 	return info
 }
 
-func parseInterface(fset *token.FileSet, name string, it *ast.InterfaceType, decl *ast.GenDecl) IfaceInfo {
+func parseInterface(fset *token.FileSet, cmap ast.CommentMap, typeSpec *ast.TypeSpec, it *ast.InterfaceType, decl *ast.GenDecl) IfaceInfo {
+	startLine, endLine := typeSpecSpan(fset, decl, typeSpec)
 	info := IfaceInfo{
-		Name:      name,
-		Methods:   make([]MethodInfo, 0),
-		StartLine: fset.Position(decl.Pos()).Line,
-		EndLine:   fset.Position(decl.End()).Line,
+		Name:        typeSpec.Name.Name,
+		Doc:         docFor(cmap, typeSpec, decl),
+		LineComment: trailingComment(fset, cmap, typeSpec),
+		TypeParams:  parseFieldList(typeSpec.TypeParams),
+		Methods:     make([]MethodInfo, 0),
+		StartLine:   startLine,
+		EndLine:     endLine,
 	}
 
 	if it.Methods != nil {
 		for _, method := range it.Methods.List {
 			if len(method.Names) == 0 {
-				continue // Embedded interface
+				// Embedded interface or a type-constraint element
+				// (e.g. "Stringer" or "~int | ~string"); typeToString
+				// already renders both faithfully.
+				info.Embedded = append(info.Embedded, typeToString(method.Type))
+				continue
 			}
 
 			funcType, ok := method.Type.(*ast.FuncType)
@@ -188,10 +308,12 @@ func parseInterface(fset *token.FileSet, name string, it *ast.InterfaceType, dec
 			}
 
 			methodInfo := MethodInfo{
-				Name:       method.Names[0].Name,
-				IsExported: ast.IsExported(method.Names[0].Name),
-				Params:     parseFieldList(funcType.Params),
-				Results:    parseFieldList(funcType.Results),
+				Name:        method.Names[0].Name,
+				Doc:         docText(method.Doc),
+				LineComment: docText(method.Comment),
+				IsExported:  ast.IsExported(method.Names[0].Name),
+				Params:      parseFieldList(funcType.Params),
+				Results:     parseFieldList(funcType.Results),
 			}
 
 			info.Methods = append(info.Methods, methodInfo)
@@ -201,13 +323,16 @@ func parseInterface(fset *token.FileSet, name string, it *ast.InterfaceType, dec
 	return info
 }
 
-func parseFunc(fset *token.FileSet, decl *ast.FuncDecl) FuncInfo {
+func parseFunc(fset *token.FileSet, cmap ast.CommentMap, decl *ast.FuncDecl) FuncInfo {
 	info := FuncInfo{
-		Name:      decl.Name.Name,
-		Params:    parseFieldList(decl.Type.Params),
-		Results:   parseFieldList(decl.Type.Results),
-		StartLine: fset.Position(decl.Pos()).Line,
-		EndLine:   fset.Position(decl.End()).Line,
+		Name:        decl.Name.Name,
+		Doc:         docText(decl.Doc),
+		LineComment: trailingComment(fset, cmap, decl),
+		TypeParams:  parseFieldList(decl.Type.TypeParams),
+		Params:      parseFieldList(decl.Type.Params),
+		Results:     parseFieldList(decl.Type.Results),
+		StartLine:   fset.Position(decl.Pos()).Line,
+		EndLine:     fset.Position(decl.End()).Line,
 	}
 
 	if decl.Recv != nil && len(decl.Recv.List) > 0 {
@@ -248,19 +373,33 @@ func parseFieldList(fl *ast.FieldList) []ParamInfo {
 	return params
 }
 
+// typeToString renders expr as the Go syntax a reader would type,
+// including generic instantiations, type constraints, and full
+// function signatures. It also doubles as a general expression
+// printer for the handful of non-type nodes that show up inside type
+// syntax, such as array lengths and constraint unions.
 func typeToString(expr ast.Expr) string {
 	switch t := expr.(type) {
 	case *ast.Ident:
 		return t.Name
+	case *ast.BasicLit:
+		return t.Value
 	case *ast.SelectorExpr:
 		return typeToString(t.X) + "." + t.Sel.Name
 	case *ast.StarExpr:
 		return "*" + typeToString(t.X)
+	case *ast.ParenExpr:
+		return "(" + typeToString(t.X) + ")"
 	case *ast.ArrayType:
-		if t.Len == nil {
+		switch {
+		case t.Len == nil:
 			return "[]" + typeToString(t.Elt)
+		default:
+			if _, ok := t.Len.(*ast.Ellipsis); ok {
+				return "[...]" + typeToString(t.Elt)
+			}
+			return "[" + typeToString(t.Len) + "]" + typeToString(t.Elt)
 		}
-		return "[...]" + typeToString(t.Elt)
 	case *ast.MapType:
 		return "map[" + typeToString(t.Key) + "]" + typeToString(t.Value)
 	case *ast.ChanType:
@@ -273,16 +412,145 @@ func typeToString(expr ast.Expr) string {
 			return "chan " + typeToString(t.Value)
 		}
 	case *ast.FuncType:
-		return "func(...)"
+		return funcSignatureString("func", t)
+	case *ast.StructType:
+		return structTypeString(t)
 	case *ast.InterfaceType:
-		return "interface{}"
+		return interfaceTypeString(t)
 	case *ast.Ellipsis:
+		if t.Elt == nil {
+			return "..."
+		}
 		return "..." + typeToString(t.Elt)
+	case *ast.IndexExpr:
+		return typeToString(t.X) + "[" + typeToString(t.Index) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, len(t.Indices))
+		for i, idx := range t.Indices {
+			args[i] = typeToString(idx)
+		}
+		return typeToString(t.X) + "[" + strings.Join(args, ", ") + "]"
+	case *ast.BinaryExpr:
+		// Union type constraints, e.g. ~int | ~string.
+		return typeToString(t.X) + " " + t.Op.String() + " " + typeToString(t.Y)
+	case *ast.UnaryExpr:
+		// Approximation constraints, e.g. ~int.
+		return t.Op.String() + typeToString(t.X)
 	default:
 		return "unknown"
 	}
 }
 
+// funcSignatureString renders a function signature as "<name>(params)
+// results", using "func" as name for a bare function type. Named
+// results are wrapped in parens to match Go syntax; a single unnamed
+// result is not.
+func funcSignatureString(name string, t *ast.FuncType) string {
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteString(typeParamsString(t.TypeParams))
+	b.WriteString("(")
+	b.WriteString(fieldListString(t.Params))
+	b.WriteString(")")
+
+	if t.Results != nil && len(t.Results.List) > 0 {
+		results := fieldListString(t.Results)
+		if len(t.Results.List) == 1 && len(t.Results.List[0].Names) == 0 {
+			b.WriteString(" ")
+			b.WriteString(results)
+		} else {
+			b.WriteString(" (")
+			b.WriteString(results)
+			b.WriteString(")")
+		}
+	}
+
+	return b.String()
+}
+
+// fieldListString renders a parameter/result/type-param list as
+// comma-separated "name Type" (or just "Type" when unnamed).
+func fieldListString(fl *ast.FieldList) string {
+	if fl == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(fl.List))
+	for _, field := range fl.List {
+		typ := typeToString(field.Type)
+		if len(field.Names) == 0 {
+			parts = append(parts, typ)
+			continue
+		}
+
+		names := make([]string, len(field.Names))
+		for i, n := range field.Names {
+			names[i] = n.Name
+		}
+		parts = append(parts, strings.Join(names, ", ")+" "+typ)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// typeParamsString renders a `[T any, ...]` type parameter list, or
+// "" when fl has none.
+func typeParamsString(fl *ast.FieldList) string {
+	if fl == nil || len(fl.List) == 0 {
+		return ""
+	}
+	return "[" + fieldListString(fl) + "]"
+}
+
+// structTypeString renders an anonymous struct type literal.
+func structTypeString(st *ast.StructType) string {
+	if st.Fields == nil || len(st.Fields.List) == 0 {
+		return "struct{}"
+	}
+
+	parts := make([]string, 0, len(st.Fields.List))
+	for _, field := range st.Fields.List {
+		typ := typeToString(field.Type)
+		if len(field.Names) == 0 {
+			parts = append(parts, typ)
+			continue
+		}
+
+		names := make([]string, len(field.Names))
+		for i, n := range field.Names {
+			names[i] = n.Name
+		}
+		parts = append(parts, strings.Join(names, ", ")+" "+typ)
+	}
+
+	return "struct{ " + strings.Join(parts, "; ") + " }"
+}
+
+// interfaceTypeString renders an anonymous interface type literal,
+// including embedded types and constraint elements.
+func interfaceTypeString(it *ast.InterfaceType) string {
+	if it.Methods == nil || len(it.Methods.List) == 0 {
+		return "interface{}"
+	}
+
+	parts := make([]string, 0, len(it.Methods.List))
+	for _, method := range it.Methods.List {
+		if len(method.Names) == 0 {
+			// Embedded interface or constraint element.
+			parts = append(parts, typeToString(method.Type))
+			continue
+		}
+
+		if funcType, ok := method.Type.(*ast.FuncType); ok {
+			parts = append(parts, funcSignatureString(method.Names[0].Name, funcType))
+			continue
+		}
+		parts = append(parts, method.Names[0].Name+" "+typeToString(method.Type))
+	}
+
+	return "interface{ " + strings.Join(parts, "; ") + " }"
+}
+
 func parseStructTag(tag string) map[string]string {
 	result := make(map[string]string)
 
@@ -0,0 +1,376 @@
+// Package reverse generates Go struct declarations that mirror a live
+// SQL database schema, so the Neovim plugin can scaffold a model
+// package from a running database without leaving the editor.
+package reverse
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Dialect selects which driver and information-schema queries to use.
+type Dialect string
+
+// Supported dialects, named after their database/sql driver name so
+// Dialect can be passed straight to sql.Open.
+const (
+	MySQL    Dialect = "mysql"
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite3"
+)
+
+// NameCase selects the casing used for generated db and json tag
+// values; xorm and gorm tags always carry the column name unchanged.
+// Struct and field names are always exported Go identifiers regardless
+// of NameCase.
+type NameCase string
+
+const (
+	CaseSnake NameCase = "snake"
+	CaseCamel NameCase = "camel"
+)
+
+// Options configures schema generation.
+type Options struct {
+	DSN     string
+	Dialect Dialect
+
+	Tables      []string // empty means every base table in the schema
+	TablePrefix string   // stripped from generated struct and file names; TableName() returns the full table name
+	Package     string   // generated package name; defaults to "models"
+
+	NameCase NameCase // casing applied to db and json tag values
+	Tags     []string // tag sets to emit per field: db, json, xorm, gorm
+
+	OutDir string // when set, Generate also writes one file per table under this directory
+}
+
+// File is a single generated Go source file. Generate always returns
+// these so the Neovim plugin can scaffold a model package even when
+// Options.OutDir is empty.
+type File struct {
+	Filename string `json:"filename"`
+	Content  string `json:"content"`
+}
+
+type column struct {
+	Name     string
+	DBType   string
+	Nullable bool
+}
+
+// Generate connects to opts.DSN, introspects every matching table,
+// and returns one generated Go file per table.
+func Generate(opts Options) ([]File, error) {
+	db, err := sql.Open(string(opts.Dialect), opts.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	tables, err := listTables(db, opts)
+	if err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+
+	files := make([]File, 0, len(tables))
+	for _, table := range tables {
+		columns, err := listColumns(db, opts.Dialect, table)
+		if err != nil {
+			return nil, fmt.Errorf("inspecting table %s: %w", table, err)
+		}
+
+		file, err := generateFile(table, columns, opts)
+		if err != nil {
+			return nil, fmt.Errorf("generating struct for %s: %w", table, err)
+		}
+		files = append(files, file)
+
+		if opts.OutDir != "" {
+			path := filepath.Join(opts.OutDir, file.Filename)
+			if err := os.WriteFile(path, []byte(file.Content), 0o644); err != nil {
+				return nil, fmt.Errorf("writing %s: %w", path, err)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+func listTables(db *sql.DB, opts Options) ([]string, error) {
+	if len(opts.Tables) > 0 {
+		return opts.Tables, nil
+	}
+
+	var query string
+	switch opts.Dialect {
+	case MySQL:
+		query = `SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'`
+	case Postgres:
+		query = `SELECT table_name FROM information_schema.tables WHERE table_schema = 'public' AND table_type = 'BASE TABLE'`
+	case SQLite:
+		query = `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`
+	default:
+		return nil, fmt.Errorf("unsupported dialect: %s", opts.Dialect)
+	}
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
+func listColumns(db *sql.DB, dialect Dialect, table string) ([]column, error) {
+	if dialect == SQLite {
+		return listColumnsSQLite(db, table)
+	}
+
+	var query string
+	switch dialect {
+	case MySQL:
+		query = `SELECT column_name, data_type, is_nullable FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position`
+	case Postgres:
+		query = `SELECT column_name, data_type, is_nullable FROM information_schema.columns WHERE table_schema = 'public' AND table_name = $1 ORDER BY ordinal_position`
+	default:
+		return nil, fmt.Errorf("unsupported dialect: %s", dialect)
+	}
+
+	rows, err := db.Query(query, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []column
+	for rows.Next() {
+		var name, dbType, nullable string
+		if err := rows.Scan(&name, &dbType, &nullable); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column{Name: name, DBType: dbType, Nullable: strings.EqualFold(nullable, "YES")})
+	}
+	return columns, rows.Err()
+}
+
+// listColumnsSQLite uses PRAGMA table_info since sqlite3 does not
+// expose a standard information_schema.
+func listColumnsSQLite(db *sql.DB, table string) ([]column, error) {
+	rows, err := db.Query(fmt.Sprintf(`PRAGMA table_info(%s)`, quoteIdent(table)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []column
+	for rows.Next() {
+		var cid, pk int
+		var notNull int
+		var name, dbType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &dbType, &notNull, &dflt, &pk); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column{Name: name, DBType: dbType, Nullable: notNull == 0})
+	}
+	return columns, rows.Err()
+}
+
+func quoteIdent(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+type templateField struct {
+	GoName string
+	GoType string
+	Tag    string
+}
+
+type templateData struct {
+	Package    string
+	StructName string
+	Table      string
+	Receiver   string
+	TableName  bool
+	Imports    []string
+	Fields     []templateField
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by nvim-go reverse from the {{.Table}} table. DO NOT EDIT.
+package {{.Package}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+// {{.StructName}} mirrors the {{.Table}} table.
+type {{.StructName}} struct {
+{{- range .Fields}}
+	{{.GoName}} {{.GoType}} ` + "`{{.Tag}}`" + `
+{{- end}}
+}
+{{if .TableName}}
+// TableName returns the underlying table name for {{.StructName}}.
+func ({{.Receiver}} {{.StructName}}) TableName() string {
+	return "{{.Table}}"
+}
+{{end}}`))
+
+func generateFile(table string, columns []column, opts Options) (File, error) {
+	structName := pascalCase(strings.TrimPrefix(table, opts.TablePrefix))
+	if structName == "" {
+		return File{}, fmt.Errorf("table %q has no name left after stripping prefix %q", table, opts.TablePrefix)
+	}
+
+	usesTime := false
+	fields := make([]templateField, 0, len(columns))
+	for _, col := range columns {
+		goType := goType(col.DBType, col.Nullable)
+		if strings.Contains(goType, "time.Time") {
+			usesTime = true
+		}
+		fields = append(fields, templateField{
+			GoName: pascalCase(col.Name),
+			GoType: goType,
+			Tag:    buildTag(col.Name, opts),
+		})
+	}
+
+	var imports []string
+	if usesTime {
+		imports = append(imports, "time")
+	}
+
+	data := templateData{
+		Package:    firstNonEmpty(opts.Package, "models"),
+		StructName: structName,
+		Table:      table,
+		Receiver:   strings.ToLower(structName[:1]),
+		TableName:  opts.TablePrefix != "",
+		Imports:    imports,
+		Fields:     fields,
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, data); err != nil {
+		return File{}, fmt.Errorf("executing template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return File{}, fmt.Errorf("formatting generated source: %w", err)
+	}
+
+	return File{
+		Filename: strings.ToLower(structName) + ".go",
+		Content:  string(formatted),
+	}, nil
+}
+
+// buildTag renders the struct tag for column across every tag set in
+// opts.Tags, defaulting to a lone "db" tag when none were requested.
+func buildTag(column string, opts Options) string {
+	name := column
+	if opts.NameCase == CaseCamel {
+		name = camelCase(column)
+	}
+
+	tagSets := opts.Tags
+	if len(tagSets) == 0 {
+		tagSets = []string{"db"}
+	}
+
+	parts := make([]string, 0, len(tagSets))
+	for _, t := range tagSets {
+		switch t {
+		case "db":
+			parts = append(parts, fmt.Sprintf(`db:"%s"`, name))
+		case "json":
+			parts = append(parts, fmt.Sprintf(`json:"%s"`, name))
+		case "xorm":
+			parts = append(parts, fmt.Sprintf(`xorm:"'%s'"`, column))
+		case "gorm":
+			parts = append(parts, fmt.Sprintf(`gorm:"column:%s"`, column))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// pascalCase renders a snake_case SQL identifier as an exported Go
+// identifier, e.g. "user_id" -> "UserID" is not attempted (no
+// acronym dictionary); it renders "user_id" -> "UserId".
+func pascalCase(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + strings.ToLower(word[1:]))
+	}
+	return b.String()
+}
+
+func camelCase(name string) string {
+	p := pascalCase(name)
+	if p == "" {
+		return p
+	}
+	return strings.ToLower(p[:1]) + p[1:]
+}
+
+func goType(dbType string, nullable bool) string {
+	base := sqlTypeToGo(dbType)
+	if nullable {
+		return "*" + base
+	}
+	return base
+}
+
+func sqlTypeToGo(dbType string) string {
+	t := strings.ToLower(dbType)
+	switch {
+	case strings.Contains(t, "int"):
+		return "int64"
+	case strings.Contains(t, "bool"):
+		return "bool"
+	case strings.Contains(t, "float"), strings.Contains(t, "double"), strings.Contains(t, "real"), strings.Contains(t, "numeric"), strings.Contains(t, "decimal"):
+		return "float64"
+	case strings.Contains(t, "time"), strings.Contains(t, "date"):
+		return "time.Time"
+	case strings.Contains(t, "blob"), strings.Contains(t, "binary"), strings.Contains(t, "bytea"):
+		return "[]byte"
+	default:
+		return "string"
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
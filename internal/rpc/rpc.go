@@ -0,0 +1,262 @@
+// Package rpc implements a minimal JSON-RPC 2.0 transport for nvim-go's
+// long-running server mode. Messages are framed with LSP-style
+// Content-Length headers over an io.Reader/io.Writer pair, so the same
+// code serves both stdio and a Unix socket connection.
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Error codes as defined by the JSON-RPC 2.0 spec.
+const (
+	ParseError     = -32700
+	InvalidRequest = -32600
+	MethodNotFound = -32601
+	InvalidParams  = -32602
+	InternalError  = -32603
+)
+
+// request is the wire shape of an incoming JSON-RPC message. A missing
+// ID marks a notification; "$/cancelRequest" is handled specially to
+// cancel an in-flight request's context.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is the wire shape of an outgoing JSON-RPC message.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error represents a JSON-RPC error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// NewError wraps err as a JSON-RPC error with the given code.
+func NewError(code int, err error) *Error {
+	return &Error{Code: code, Message: err.Error()}
+}
+
+// cancelParams is the payload of a "$/cancelRequest" notification.
+type cancelParams struct {
+	ID json.RawMessage `json:"id"`
+}
+
+// Handler processes the params of a single request or notification and
+// returns a result to be marshaled back, or an error. Handlers should
+// respect ctx cancellation for long-running work.
+type Handler func(ctx context.Context, params json.RawMessage) (any, error)
+
+// Server dispatches framed JSON-RPC messages to registered handlers.
+// A Server is safe for use by a single Serve call; register handlers
+// before calling Serve.
+type Server struct {
+	handlers map[string]Handler
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// NewServer returns a Server with no handlers registered.
+func NewServer() *Server {
+	return &Server{
+		handlers: make(map[string]Handler),
+		cancels:  make(map[string]context.CancelFunc),
+	}
+}
+
+// Handle registers h to serve requests and notifications for method.
+func (s *Server) Handle(method string, h Handler) {
+	s.handlers[method] = h
+}
+
+// Serve reads framed JSON-RPC messages from r and writes responses to
+// w until r is exhausted or a framing error occurs. Requests run in
+// their own goroutine so a slow request does not block others, and so
+// that a later "$/cancelRequest" notification can reach it.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	var wg sync.WaitGroup
+	var writeMu sync.Mutex
+
+	br := bufio.NewReader(r)
+	for {
+		req, err := readMessage(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading message: %w", err)
+		}
+
+		if req.Method == "$/cancelRequest" {
+			s.handleCancel(req.Params)
+			continue
+		}
+
+		wg.Add(1)
+		go func(req *request) {
+			defer wg.Done()
+			s.dispatch(req, w, &writeMu)
+		}(req)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+func (s *Server) dispatch(req *request, w io.Writer, writeMu *sync.Mutex) {
+	ctx := context.Background()
+	if req.ID != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		s.setCancel(string(req.ID), cancel)
+		defer s.clearCancel(string(req.ID))
+	}
+
+	handler, ok := s.handlers[req.Method]
+	if !ok {
+		if req.ID != nil {
+			s.reply(w, writeMu, req, nil, &Error{Code: MethodNotFound, Message: "method not found: " + req.Method})
+		}
+		return
+	}
+
+	result, err := handler(ctx, req.Params)
+	if req.ID == nil {
+		// Notification: no response is sent, even on error.
+		return
+	}
+
+	if err != nil {
+		var rpcErr *Error
+		if !asError(err, &rpcErr) {
+			rpcErr = NewError(InternalError, err)
+		}
+		s.reply(w, writeMu, req, nil, rpcErr)
+		return
+	}
+
+	s.reply(w, writeMu, req, result, nil)
+}
+
+func asError(err error, target **Error) bool {
+	if e, ok := err.(*Error); ok {
+		*target = e
+		return true
+	}
+	return false
+}
+
+func (s *Server) reply(w io.Writer, writeMu *sync.Mutex, req *request, result any, rpcErr *Error) {
+	resp := &response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr}
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	if err := writeMessage(w, resp); err != nil {
+		// Nothing left to do but drop the response; the client will
+		// see the connection close.
+		return
+	}
+}
+
+func (s *Server) setCancel(id string, cancel context.CancelFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancels[id] = cancel
+}
+
+func (s *Server) clearCancel(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cancels, id)
+}
+
+func (s *Server) handleCancel(params json.RawMessage) {
+	var p cancelParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	cancel, ok := s.cancels[string(p.ID)]
+	s.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// readMessage reads one Content-Length framed message from r.
+func readMessage(r *bufio.Reader) (*request, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length: %w", err)
+			}
+			length = n
+		}
+	}
+
+	if length < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("decoding request: %w", err)
+	}
+	return &req, nil
+}
+
+// writeMessage frames and writes v to w as a Content-Length delimited
+// JSON-RPC message.
+func writeMessage(w io.Writer, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,347 @@
+// Package tags rewrites Go struct field tags in place, mirroring the
+// add/remove/rename workflow of gomodifytags so the Neovim plugin can
+// offer the same struct-tag editing without shelling out to it.
+package tags
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Transform names a struct field tag value transform applied to the
+// Go field name when generating a new tag value.
+type Transform string
+
+// Supported name transforms. TransformKeep leaves the field name as
+// written, matching gomodifytags' "keep" mode.
+const (
+	TransformKeep   Transform = "keep"
+	TransformSnake  Transform = "snakecase"
+	TransformCamel  Transform = "camelcase"
+	TransformPascal Transform = "pascalcase"
+	TransformKebab  Transform = "kebabcase"
+)
+
+// Options configures a tag rewrite.
+type Options struct {
+	File   string
+	Line   int
+	Add    []string
+	Remove []string
+
+	Transform  Transform
+	TagOptions map[string][]string
+
+	SkipUnexported bool
+	SkipEmbedded   bool
+}
+
+// Edit describes the tag rewrite made to a single struct field.
+type Edit struct {
+	Line   int    `json:"line"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Result is the outcome of applying Options to a file.
+type Result struct {
+	Edits  []Edit `json:"edits"`
+	Source string `json:"source"`
+}
+
+// Apply parses opts.File, rewrites the tags on every eligible field of
+// the struct whose declaration covers opts.Line, and returns the
+// formatted source together with a list of the edits made.
+func Apply(opts Options) (*Result, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, opts.File, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing file: %w", err)
+	}
+
+	st, ok := findStructAt(fset, file, opts.Line)
+	if !ok {
+		return nil, fmt.Errorf("no struct found at line %d", opts.Line)
+	}
+
+	var edits []Edit
+	for _, field := range st.Fields.List {
+		if skipField(field, opts) {
+			continue
+		}
+
+		before := ""
+		if field.Tag != nil {
+			before = strings.Trim(field.Tag.Value, "`")
+		}
+
+		after := rewriteTag(before, fieldName(field), opts)
+		if after == before {
+			continue
+		}
+
+		if after == "" {
+			field.Tag = nil
+		} else {
+			field.Tag = &ast.BasicLit{Kind: token.STRING, Value: "`" + after + "`"}
+		}
+
+		edits = append(edits, Edit{
+			Line:   fset.Position(field.Pos()).Line,
+			Before: before,
+			After:  after,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("formatting result: %w", err)
+	}
+
+	return &Result{Edits: edits, Source: buf.String()}, nil
+}
+
+// findStructAt returns the struct type whose type spec spans line,
+// the same lines parser.StructInfo reports as StartLine/EndLine.
+func findStructAt(fset *token.FileSet, file *ast.File, line int) (*ast.StructType, bool) {
+	var found *ast.StructType
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+
+		decl, ok := n.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			return true
+		}
+
+		for _, spec := range decl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			// Match against the spec's own span, not the enclosing
+			// GenDecl's: a grouped `type ( ... )` block can hold
+			// several specs, and line may fall inside just one of
+			// them.
+			if line < fset.Position(typeSpec.Pos()).Line || line > fset.Position(typeSpec.End()).Line {
+				continue
+			}
+			if st, ok := typeSpec.Type.(*ast.StructType); ok {
+				found = st
+				return false
+			}
+		}
+		return true
+	})
+
+	return found, found != nil
+}
+
+func skipField(field *ast.Field, opts Options) bool {
+	if len(field.Names) == 0 {
+		return opts.SkipEmbedded
+	}
+	if opts.SkipUnexported && !ast.IsExported(field.Names[0].Name) {
+		return true
+	}
+	return false
+}
+
+func fieldName(field *ast.Field) string {
+	if len(field.Names) == 0 {
+		return ""
+	}
+	return field.Names[0].Name
+}
+
+// rewriteTag applies opts.Remove then opts.Add to the tag string
+// current and returns the new raw tag content (without backticks).
+func rewriteTag(current, fieldName string, opts Options) string {
+	entries := parseTag(current)
+
+	for _, key := range opts.Remove {
+		entries = removeEntry(entries, key)
+	}
+
+	for _, key := range opts.Add {
+		entries = upsertEntry(entries, key, transformName(fieldName, opts.Transform), opts.TagOptions[key])
+	}
+
+	return writeTag(entries)
+}
+
+func removeEntry(entries []tagEntry, key string) []tagEntry {
+	for i, e := range entries {
+		if e.Key == key {
+			return append(entries[:i], entries[i+1:]...)
+		}
+	}
+	return entries
+}
+
+func upsertEntry(entries []tagEntry, key, name string, options []string) []tagEntry {
+	for i, e := range entries {
+		if e.Key == key {
+			entries[i].Options = mergeOptions(e.Options, options)
+			return entries
+		}
+	}
+	return append(entries, tagEntry{Key: key, Name: name, Options: options})
+}
+
+func mergeOptions(existing, add []string) []string {
+	seen := make(map[string]bool, len(existing))
+	for _, o := range existing {
+		seen[o] = true
+	}
+	for _, o := range add {
+		if !seen[o] {
+			existing = append(existing, o)
+			seen[o] = true
+		}
+	}
+	return existing
+}
+
+// tagEntry is one key:"name,opt,opt" pair within a struct tag.
+type tagEntry struct {
+	Key     string
+	Name    string
+	Options []string
+}
+
+// parseTag reads a raw struct tag (without surrounding backticks) into
+// an ordered list of entries, unlike the lossy key->value map that
+// parser.parseStructTag builds for read-only JSON output: a rewrite
+// needs to preserve key order and per-key options exactly.
+func parseTag(tag string) []tagEntry {
+	var entries []tagEntry
+
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		quoted := tag[:i+1]
+		tag = tag[i+1:]
+
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			break
+		}
+
+		name, options := value, []string(nil)
+		if idx := strings.Index(value, ","); idx >= 0 {
+			name = value[:idx]
+			options = strings.Split(value[idx+1:], ",")
+		}
+
+		entries = append(entries, tagEntry{Key: key, Name: name, Options: options})
+	}
+
+	return entries
+}
+
+// writeTag renders entries back into raw struct tag syntax.
+func writeTag(entries []tagEntry) string {
+	parts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		value := e.Name
+		if len(e.Options) > 0 {
+			value += "," + strings.Join(e.Options, ",")
+		}
+		parts = append(parts, e.Key+":"+strconv.Quote(value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// transformName renders name under t. TransformKeep (and any unknown
+// transform) returns name unchanged.
+func transformName(name string, t Transform) string {
+	switch t {
+	case TransformSnake:
+		return strings.ToLower(strings.Join(splitWords(name), "_"))
+	case TransformKebab:
+		return strings.ToLower(strings.Join(splitWords(name), "-"))
+	case TransformCamel:
+		return joinCamel(splitWords(name), false)
+	case TransformPascal:
+		return joinCamel(splitWords(name), true)
+	default:
+		return name
+	}
+}
+
+func joinCamel(words []string, pascal bool) string {
+	var b strings.Builder
+	for i, w := range words {
+		lower := strings.ToLower(w)
+		if i == 0 && !pascal {
+			b.WriteString(lower)
+			continue
+		}
+		b.WriteString(strings.ToUpper(lower[:1]) + lower[1:])
+	}
+	return b.String()
+}
+
+// splitWords splits a Go identifier like "UserID" into its
+// constituent words ("User", "ID"), treating a run of uppercase
+// letters followed by a lowercase letter as the start of a new word
+// (so "HTTPServer" splits into "HTTP", "Server", not "H", "T", ...).
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	var current []rune
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prevLower := !unicode.IsUpper(current[len(current)-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+
+	return words
+}